@@ -4,22 +4,81 @@ package main
 //Temporary simplified main file
 import (
 	"fmt"
+	"log"
+	"log/slog"
+	"net"
 	"net/http"
 
+	"github.com/JSh4w/financial-analyzer/internal/config"
+	"github.com/JSh4w/financial-analyzer/internal/handlers"
+	"github.com/JSh4w/financial-analyzer/internal/middleware"
+	"github.com/JSh4w/financial-analyzer/pkg/utils"
 	"github.com/gorilla/mux"
 )
 
 func HealthHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-type", "application/json")
-	w.Write([]byte(`{"status":"ok"}`))
+	utils.WriteJSON(w, r, http.StatusOK, map[string]string{"status": "ok"})
 }
 
 func main() {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	srv := newServer(cfg, newRouter(cfg))
+	ln, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		log.Fatalf("Failed to listen on %s: %v", srv.Addr, err)
+	}
+
+	fmt.Printf("Starting Go API server on %s (tls: %t)\n", srv.Addr, cfg.TLSEnabled())
+	if err := serve(srv, ln, cfg); err != nil && err != http.ErrServerClosed {
+		log.Fatalf("Failed to start server: %v", err)
+	}
+}
+
+func newRouter(cfg *config.Config) *mux.Router {
 	router := mux.NewRouter()
+	// mux only runs router.Use middleware on matched routes, so the 404 and
+	// 405 handlers are wrapped in the same chain directly.
+	unmatched := func(h http.Handler) http.Handler {
+		h = middleware.PrettyJSON(cfg.PrettyJSON)(h)
+		h = middleware.Recovery(slog.Default())(h)
+		return middleware.RealIP(cfg.TrustedProxies)(h)
+	}
+	router.NotFoundHandler = unmatched(handlers.NotFound())
+	router.MethodNotAllowedHandler = unmatched(handlers.MethodNotAllowed(router))
+
+	// RealIP only parses addresses and runs outside Recovery so panic logs
+	// carry the resolved client IP; Recovery covers everything after it.
+	router.Use(middleware.RealIP(cfg.TrustedProxies))
+	router.Use(middleware.Recovery(slog.Default()))
+	router.Use(middleware.PrettyJSON(cfg.PrettyJSON))
+	router.Use(middleware.ContentTypes(cfg.AllowedContentTypes))
 	router.HandleFunc("/health", HealthHandler).Methods("GET")
 
-	fmt.Println("Starting Go API server on :8080")
-	http.ListenAndServe(":8080", router)
+	return router
+}
+
+func newServer(cfg *config.Config, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              ":8080",
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+	}
+}
+
+// serve terminates TLS itself when a cert/key pair is configured, which
+// also enables HTTP/2; otherwise it serves plain HTTP.
+func serve(srv *http.Server, ln net.Listener, cfg *config.Config) error {
+	if cfg.TLSEnabled() {
+		return srv.ServeTLS(ln, cfg.TLSCertFile, cfg.TLSKeyFile)
+	}
+	return srv.Serve(ln)
 }
 
 // Partly implemeneted Go features
@@ -0,0 +1,189 @@
+// Package config loads the Go API settings from environment variables.
+package config
+
+import (
+	"fmt"
+	"mime"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default HTTP server timeouts. The header timeout is kept short to cut off
+// slowloris-style clients; the write timeout leaves room for slower upstream
+// calls; idle keep-alive connections are reaped after two minutes.
+const (
+	defaultReadHeaderTimeout = 5 * time.Second
+	defaultReadTimeout       = 15 * time.Second
+	defaultWriteTimeout      = 30 * time.Second
+	defaultIdleTimeout       = 120 * time.Second
+)
+
+// Config holds the runtime settings for the API server.
+type Config struct {
+	// TrustedProxies are the peers allowed to set X-Forwarded-For.
+	// Set via TRUSTED_PROXIES as a comma-separated list of CIDRs or IPs.
+	TrustedProxies []netip.Prefix
+
+	// TLSCertFile and TLSKeyFile enable TLS when both are set
+	// (TLS_CERT_FILE, TLS_KEY_FILE).
+	TLSCertFile string
+	TLSKeyFile  string
+
+	// HTTP server timeouts, as Go durations (e.g. "10s"). Set via
+	// HTTP_READ_HEADER_TIMEOUT, HTTP_READ_TIMEOUT, HTTP_WRITE_TIMEOUT and
+	// HTTP_IDLE_TIMEOUT.
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+
+	// AllowedContentTypes are the media types accepted for request bodies.
+	// Set via ALLOWED_CONTENT_TYPES; defaults to application/json.
+	AllowedContentTypes []string
+
+	// PrettyJSON indents JSON responses by default (PRETTY_JSON). Meant for
+	// local development; clients can always pass ?pretty=true.
+	PrettyJSON bool
+}
+
+// EnvVars lists every environment variable LoadConfig reads. Tests use it
+// to isolate themselves from the developer's environment, so keep it in sync
+// when adding settings.
+var EnvVars = []string{
+	"TRUSTED_PROXIES",
+	"TLS_CERT_FILE",
+	"TLS_KEY_FILE",
+	"HTTP_READ_HEADER_TIMEOUT",
+	"HTTP_READ_TIMEOUT",
+	"HTTP_WRITE_TIMEOUT",
+	"HTTP_IDLE_TIMEOUT",
+	"ALLOWED_CONTENT_TYPES",
+	"PRETTY_JSON",
+}
+
+// LoadConfig reads the configuration from the environment.
+func LoadConfig() (*Config, error) {
+	proxies, err := parsePrefixes(os.Getenv("TRUSTED_PROXIES"))
+	if err != nil {
+		return nil, fmt.Errorf("TRUSTED_PROXIES: %w", err)
+	}
+
+	cfg := &Config{
+		TrustedProxies: proxies,
+		TLSCertFile:    os.Getenv("TLS_CERT_FILE"),
+		TLSKeyFile:     os.Getenv("TLS_KEY_FILE"),
+	}
+	timeouts := []struct {
+		env      string
+		dst      *time.Duration
+		fallback time.Duration
+	}{
+		{"HTTP_READ_HEADER_TIMEOUT", &cfg.ReadHeaderTimeout, defaultReadHeaderTimeout},
+		{"HTTP_READ_TIMEOUT", &cfg.ReadTimeout, defaultReadTimeout},
+		{"HTTP_WRITE_TIMEOUT", &cfg.WriteTimeout, defaultWriteTimeout},
+		{"HTTP_IDLE_TIMEOUT", &cfg.IdleTimeout, defaultIdleTimeout},
+	}
+	for _, setting := range timeouts {
+		if *setting.dst, err = parseDuration(os.Getenv(setting.env), setting.fallback); err != nil {
+			return nil, fmt.Errorf("%s: %w", setting.env, err)
+		}
+	}
+
+	if cfg.AllowedContentTypes, err = parseMediaTypes(os.Getenv("ALLOWED_CONTENT_TYPES")); err != nil {
+		return nil, fmt.Errorf("ALLOWED_CONTENT_TYPES: %w", err)
+	}
+	if len(cfg.AllowedContentTypes) == 0 {
+		cfg.AllowedContentTypes = []string{"application/json"}
+	}
+
+	if value := os.Getenv("PRETTY_JSON"); value != "" {
+		if cfg.PrettyJSON, err = strconv.ParseBool(value); err != nil {
+			return nil, fmt.Errorf("PRETTY_JSON: %w", err)
+		}
+	}
+
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		return nil, fmt.Errorf("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+
+	return cfg, nil
+}
+
+// TLSEnabled reports whether the server should terminate TLS itself.
+func (c *Config) TLSEnabled() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
+// parseDuration parses a positive duration, using fallback when value is empty.
+func parseDuration(value string, fallback time.Duration) (time.Duration, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("must be positive, got %s", value)
+	}
+	return d, nil
+}
+
+// parseList splits a comma-separated value, dropping empty entries.
+func parseList(value string) []string {
+	var items []string
+	for _, field := range strings.Split(value, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			items = append(items, field)
+		}
+	}
+	return items
+}
+
+// parseMediaTypes parses a comma-separated list of media types into their
+// bare lowercase form, dropping parameters such as charset. Request headers
+// are normalised the same way before matching.
+func parseMediaTypes(value string) ([]string, error) {
+	var mediaTypes []string
+	for _, field := range parseList(value) {
+		mediaType, _, err := mime.ParseMediaType(field)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", field, err)
+		}
+		if !strings.Contains(mediaType, "/") {
+			return nil, fmt.Errorf("%q: missing subtype", field)
+		}
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	return mediaTypes, nil
+}
+
+// parsePrefixes parses a comma-separated list of CIDRs; bare IPs are
+// treated as single-address prefixes. IPv4-mapped IPv6 forms are converted
+// to plain IPv4, since peers are unmapped before matching.
+func parsePrefixes(value string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, field := range parseList(value) {
+		if !strings.Contains(field, "/") {
+			addr, err := netip.ParseAddr(field)
+			if err != nil {
+				return nil, err
+			}
+			addr = addr.Unmap()
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(field)
+		if err != nil {
+			return nil, err
+		}
+		if prefix.Addr().Is4In6() && prefix.Bits() >= 96 {
+			prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
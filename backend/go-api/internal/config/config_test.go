@@ -0,0 +1,135 @@
+package config
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// clearEnv blanks every variable LoadConfig reads so tests don't depend on
+// the developer's environment.
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, env := range EnvVars {
+		t.Setenv(env, "")
+	}
+}
+
+func TestLoadConfigTimeouts(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"default", "", defaultReadTimeout, false},
+		{"valid", "45s", 45 * time.Second, false},
+		{"invalid", "soon", 0, true},
+		{"missing unit", "10", 0, true},
+		{"zero", "0s", 0, true},
+		{"negative", "-5s", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			t.Setenv("HTTP_READ_TIMEOUT", tt.value)
+
+			cfg, err := LoadConfig()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for HTTP_READ_TIMEOUT=%q", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.ReadTimeout != tt.want {
+				t.Errorf("ReadTimeout = %v, want %v", cfg.ReadTimeout, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigTrustedProxies(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []netip.Prefix
+		wantErr bool
+	}{
+		{"unset", "", nil, false},
+		{"cidr", "10.0.0.0/8", []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}, false},
+		{"unmasked cidr", "10.1.2.3/8", []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}, false},
+		{"bare ipv4", "192.168.1.10", []netip.Prefix{netip.MustParsePrefix("192.168.1.10/32")}, false},
+		{"bare ipv6", "2001:db8::1", []netip.Prefix{netip.MustParsePrefix("2001:db8::1/128")}, false},
+		{"mapped ip", "::ffff:10.0.0.2", []netip.Prefix{netip.MustParsePrefix("10.0.0.2/32")}, false},
+		{"mapped cidr", "::ffff:10.0.0.0/104", []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}, false},
+		{"list", " 10.0.0.0/8, 172.16.0.1 ,", []netip.Prefix{
+			netip.MustParsePrefix("10.0.0.0/8"),
+			netip.MustParsePrefix("172.16.0.1/32"),
+		}, false},
+		{"bad ip", "10.0.0.300", nil, true},
+		{"bad cidr", "10.0.0.0/33", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			t.Setenv("TRUSTED_PROXIES", tt.value)
+
+			cfg, err := LoadConfig()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for TRUSTED_PROXIES=%q", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(cfg.TrustedProxies, tt.want) {
+				t.Errorf("TrustedProxies = %v, want %v", cfg.TrustedProxies, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigAllowedContentTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{"default", "", []string{"application/json"}, false},
+		{"parameters stripped", "application/json; charset=utf-8", []string{"application/json"}, false},
+		{"lowercased list", "Application/JSON, text/csv", []string{"application/json", "text/csv"}, false},
+		{"missing subtype", "json", nil, true},
+		{"malformed", "application/json; charset", nil, true},
+		{"invalid characters", "application/js on", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			t.Setenv("ALLOWED_CONTENT_TYPES", tt.value)
+
+			cfg, err := LoadConfig()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for ALLOWED_CONTENT_TYPES=%q", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(cfg.AllowedContentTypes, tt.want) {
+				t.Errorf("AllowedContentTypes = %v, want %v", cfg.AllowedContentTypes, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Package handlers contains the HTTP handlers for the API routes.
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/JSh4w/financial-analyzer/pkg/utils"
+	"github.com/gorilla/mux"
+)
+
+// MethodNotAllowed is used as the router's MethodNotAllowedHandler. It is
+// only reached when the path matches a route but the method does not, and
+// answers with the methods the path does support: 204 for OPTIONS
+// preflights, 405 for anything else.
+func MethodNotAllowed(router *mux.Router) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Allow", strings.Join(allowedMethods(router, r), ", "))
+
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		utils.WriteError(w, r, http.StatusMethodNotAllowed, "method "+r.Method+" not allowed")
+	})
+}
+
+// allowedMethods lists every method registered on routes matching r's path.
+func allowedMethods(router *mux.Router, r *http.Request) []string {
+	allowed := map[string]bool{http.MethodOptions: true}
+
+	router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		methods, err := route.GetMethods()
+		if err != nil {
+			// Route has no method matcher.
+			return nil
+		}
+		for _, method := range methods {
+			if allowed[method] {
+				continue
+			}
+			probe := r.Clone(r.Context())
+			probe.Method = method
+			if route.Match(probe, &mux.RouteMatch{}) {
+				allowed[method] = true
+			}
+		}
+		return nil
+	})
+
+	methods := make([]string, 0, len(allowed))
+	for method := range allowed {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return methods
+}
@@ -0,0 +1,15 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/JSh4w/financial-analyzer/pkg/utils"
+)
+
+// NotFound is used as the router's NotFoundHandler so unknown paths get the
+// same JSON error envelope as other failures.
+func NotFound() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		utils.WriteError(w, r, http.StatusNotFound, "path "+r.URL.Path+" not found")
+	})
+}
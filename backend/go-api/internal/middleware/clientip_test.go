@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func TestRealIP(t *testing.T) {
+	trusted := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		xff        string
+		want       string
+	}{
+		{"untrusted peer without XFF", "203.0.113.5:4000", "", "203.0.113.5"},
+		{"untrusted peer spoofing XFF", "203.0.113.5:4000", "1.2.3.4", "203.0.113.5"},
+		{"trusted peer with XFF", "10.0.0.2:4000", "198.51.100.7", "198.51.100.7"},
+		{"trusted peer without XFF", "10.0.0.2:4000", "", "10.0.0.2"},
+		{"client spoofing through trusted proxy", "10.0.0.2:4000", "1.2.3.4, 198.51.100.7", "198.51.100.7"},
+		{"chain of trusted proxies", "10.0.0.2:4000", "198.51.100.7, 10.0.0.9", "198.51.100.7"},
+		{"malformed XFF from trusted peer", "10.0.0.2:4000", "not-an-ip", "10.0.0.2"},
+		{"mapped untrusted peer", "[::ffff:203.0.113.5]:4000", "1.2.3.4", "203.0.113.5"},
+		{"mapped trusted peer without XFF", "[::ffff:10.0.0.2]:4000", "", "10.0.0.2"},
+		{"mapped trusted peer with XFF", "[::ffff:10.0.0.2]:4000", "198.51.100.7", "198.51.100.7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := RealIP(trusted)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = ClientIP(r)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/health", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.xff != "" {
+				req.Header.Set("X-Forwarded-For", tt.xff)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Errorf("ClientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/JSh4w/financial-analyzer/pkg/utils"
+)
+
+// ContentTypes rejects POST, PUT and PATCH requests with a body whose
+// Content-Type is not in allowed, responding 415. Paths in exempt skip the
+// check, for endpoints that accept other payloads such as file uploads.
+func ContentTypes(allowed []string, exempt ...string) func(http.Handler) http.Handler {
+	allowedSet := make(map[string]bool, len(allowed))
+	for _, mediaType := range allowed {
+		// Compare bare media types, as the request header is parsed below.
+		if parsed, _, err := mime.ParseMediaType(mediaType); err == nil {
+			mediaType = parsed
+		}
+		allowedSet[strings.ToLower(mediaType)] = true
+	}
+	exemptSet := make(map[string]bool, len(exempt))
+	for _, path := range exempt {
+		exemptSet[path] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !hasBody(r) || exemptSet[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if err != nil || !allowedSet[mediaType] {
+				utils.WriteError(w, r, http.StatusUnsupportedMediaType,
+					"unsupported content type, expected one of: "+strings.Join(allowed, ", "))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func hasBody(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return r.ContentLength != 0
+	}
+	return false
+}
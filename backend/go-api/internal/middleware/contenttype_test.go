@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestContentTypes(t *testing.T) {
+	handler := ContentTypes([]string{"application/json"}, "/api/import")(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		body        string
+		want        int
+	}{
+		{"json", http.MethodPost, "/api/items", "application/json", `{}`, http.StatusOK},
+		{"json with charset", http.MethodPut, "/api/items", "application/json; charset=utf-8", `{}`, http.StatusOK},
+		{"form encoded", http.MethodPost, "/api/items", "application/x-www-form-urlencoded", "a=1", http.StatusUnsupportedMediaType},
+		{"missing content type", http.MethodPatch, "/api/items", "", `{}`, http.StatusUnsupportedMediaType},
+		{"empty body", http.MethodPost, "/api/items", "", "", http.StatusOK},
+		{"get ignored", http.MethodGet, "/api/items", "text/plain", "x", http.StatusOK},
+		{"exempt path", http.MethodPost, "/api/import", "text/csv", "a,b", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestContentTypesAllowlistWithParameters(t *testing.T) {
+	handler := ContentTypes([]string{"application/json; charset=utf-8"})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/items", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/JSh4w/financial-analyzer/pkg/utils"
+)
+
+// PrettyJSON sets whether JSON responses are indented by default. Clients
+// can still override it per request with the pretty query param.
+func PrettyJSON(enabled bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(utils.WithPrettyDefault(r.Context(), enabled)))
+		})
+	}
+}
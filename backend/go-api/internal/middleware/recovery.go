@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/JSh4w/financial-analyzer/pkg/utils"
+)
+
+// Recovery recovers from panics in downstream handlers, logs the panic
+// value and stack trace, and returns a generic 500 error envelope so no
+// internals leak to the client.
+func Recovery(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+				if rec == http.ErrAbortHandler {
+					// Deliberate abort; let net/http handle it.
+					panic(rec)
+				}
+
+				logger.Error("panic recovered",
+					"request_id", r.Header.Get("X-Request-ID"),
+					"method", r.Method,
+					"path", r.URL.Path,
+					"client_ip", ClientIP(r),
+					"panic", rec,
+					"stack", string(debug.Stack()),
+				)
+				utils.WriteError(w, r, http.StatusInternalServerError, "internal server error")
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
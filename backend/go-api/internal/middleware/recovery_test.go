@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRecovery(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+
+	handler := Recovery(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("database exploded")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	var body map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("response is not JSON: %v", err)
+	}
+	if body["error"] != "internal server error" {
+		t.Errorf("error = %q, want %q", body["error"], "internal server error")
+	}
+	if strings.Contains(rec.Body.String(), "database exploded") {
+		t.Error("panic value leaked to the client")
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(logs.Bytes(), &entry); err != nil {
+		t.Fatalf("expected one JSON log entry, got %q", logs.String())
+	}
+	if entry["request_id"] != "req-123" {
+		t.Errorf("logged request_id = %v, want req-123", entry["request_id"])
+	}
+	if entry["panic"] != "database exploded" {
+		t.Errorf("logged panic = %v, want %q", entry["panic"], "database exploded")
+	}
+	if stack, _ := entry["stack"].(string); !strings.Contains(stack, "recovery_test.go") {
+		t.Error("logged stack trace does not include the panicking handler")
+	}
+}
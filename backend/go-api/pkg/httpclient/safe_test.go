@@ -0,0 +1,80 @@
+package httpclient
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr   string
+		public bool
+	}{
+		{"127.0.0.1", false},
+		{"169.254.169.254", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::1", false},
+		{"fd00:ec2::254", false},
+		{"fe80::1", false},
+		{"::ffff:127.0.0.1", false},
+		{"64:ff9b::a00:1", false},
+		{"64:ff9b:1::a00:1", false},
+		{"::ffff:0:a00:1", false},
+		{"8.8.8.8", true},
+		{"2606:4700:4700::1111", true},
+	}
+
+	for _, tt := range tests {
+		if got := IsPublicAddr(netip.MustParseAddr(tt.addr)); got != tt.public {
+			t.Errorf("IsPublicAddr(%s) = %v, want %v", tt.addr, got, tt.public)
+		}
+	}
+}
+
+func TestSafeHTTPClientRejectsUnsafeDestinations(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := NewSafeHTTPClient(2 * time.Second)
+	urls := []string{
+		srv.URL,
+		"http://169.254.169.254/latest/meta-data/",
+		"http://10.0.0.1/",
+		"http://192.168.0.1/",
+		"http://[::1]/",
+	}
+
+	for _, u := range urls {
+		resp, err := client.Get(u)
+		if err == nil {
+			resp.Body.Close()
+			t.Errorf("GET %s: expected error, got status %d", u, resp.StatusCode)
+			continue
+		}
+		if !errors.Is(err, ErrUnsafeAddress) {
+			t.Errorf("GET %s: expected ErrUnsafeAddress, got %v", u, err)
+		}
+	}
+}
+
+func TestCheckRedirectRejectsUnsafeTarget(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://169.254.169.254/", nil)
+	if err := checkRedirect(req, nil); !errors.Is(err, ErrUnsafeAddress) {
+		t.Errorf("expected ErrUnsafeAddress, got %v", err)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "file:///etc/passwd", nil)
+	if err := checkRedirect(req, nil); !errors.Is(err, ErrUnsafeAddress) {
+		t.Errorf("expected ErrUnsafeAddress for file scheme, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+// The guard lives in an external test package so it can import response
+// types from any package, including ones that depend on utils.
+package utils_test
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/JSh4w/financial-analyzer/pkg/utils"
+)
+
+// responseDTOs lists every type serialised in API responses. Add new
+// response structs here so their JSON tags are checked.
+var responseDTOs = []any{
+	utils.ErrorResponse{},
+}
+
+var snakeCase = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+
+func TestResponseDTOsUseSnakeCaseJSONTags(t *testing.T) {
+	for _, dto := range responseDTOs {
+		checkJSONTags(t, reflect.TypeOf(dto), map[reflect.Type]bool{})
+	}
+}
+
+func checkJSONTags(t *testing.T, typ reflect.Type, seen map[reflect.Type]bool) {
+	t.Helper()
+
+	for typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice ||
+		typ.Kind() == reflect.Array || typ.Kind() == reflect.Map {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct || seen[typ] {
+		return
+	}
+	seen[typ] = true
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup("json")
+		name, _, _ := strings.Cut(tag, ",")
+		switch {
+		case name == "-":
+			continue
+		case field.Anonymous && name == "":
+			// Embedded fields are flattened into the parent object.
+		case !ok || name == "":
+			t.Errorf("%s.%s has no json tag", typ.Name(), field.Name)
+		case !snakeCase.MatchString(name):
+			t.Errorf("%s.%s json tag %q is not snake_case", typ.Name(), field.Name, name)
+		}
+		checkJSONTags(t, field.Type, seen)
+	}
+}
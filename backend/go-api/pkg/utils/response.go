@@ -0,0 +1,50 @@
+// Package utils holds small helpers shared across the API packages.
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// ErrorResponse is the JSON envelope returned for failed requests.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+type prettyKey struct{}
+
+// WithPrettyDefault sets whether responses for this request are indented
+// when the client does not pass a pretty query param.
+func WithPrettyDefault(ctx context.Context, pretty bool) context.Context {
+	return context.WithValue(ctx, prettyKey{}, pretty)
+}
+
+// WriteJSON writes v as a JSON response with the given status code. Output
+// is compact unless pretty=true is passed or enabled as the default.
+func WriteJSON(w http.ResponseWriter, r *http.Request, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	enc := json.NewEncoder(w)
+	if wantPretty(r) {
+		enc.SetIndent("", "  ")
+	}
+	enc.Encode(v)
+}
+
+// WriteError writes an ErrorResponse with the given status code.
+func WriteError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	WriteJSON(w, r, status, ErrorResponse{Error: message})
+}
+
+func wantPretty(r *http.Request) bool {
+	if value := r.URL.Query().Get("pretty"); value != "" {
+		if pretty, err := strconv.ParseBool(value); err == nil {
+			return pretty
+		}
+	}
+	pretty, _ := r.Context().Value(prettyKey{}).(bool)
+	return pretty
+}
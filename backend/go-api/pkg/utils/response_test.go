@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWriteJSONPretty(t *testing.T) {
+	body := map[string]string{"status": "ok"}
+	compact := "{\"status\":\"ok\"}\n"
+	indented := "{\n  \"status\": \"ok\"\n}\n"
+
+	tests := []struct {
+		name          string
+		url           string
+		prettyDefault bool
+		want          string
+	}{
+		{"compact by default", "/health", false, compact},
+		{"pretty param", "/health?pretty=true", false, indented},
+		{"pretty default", "/health", true, indented},
+		{"param overrides default", "/health?pretty=false", true, compact},
+		{"invalid param falls back to default", "/health?pretty=maybe", false, compact},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			req = req.WithContext(WithPrettyDefault(req.Context(), tt.prettyDefault))
+			rec := httptest.NewRecorder()
+
+			WriteJSON(rec, req, http.StatusOK, body)
+
+			if got := rec.Body.String(); got != tt.want {
+				t.Errorf("body = %q, want %q", got, tt.want)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", ct)
+			}
+		})
+	}
+}